@@ -0,0 +1,28 @@
+package translate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Cache stores previously translated text so repeated (sourceLang,
+// targetLang, text) lookups don't re-hit a paid backend such as
+// TranslateByGoogle, which bills per character.
+type Cache interface {
+	// Get returns the cached translation for key, if present and not expired.
+	Get(key string) (string, bool)
+	// Set stores value under key for ttl. A zero ttl means "never expires".
+	Set(key string, value string, ttl time.Duration)
+}
+
+// CacheKey derives the lookup key for a single (sourceLang, targetLang,
+// text) tuple. It is "sourceLang|targetLang|hash", where hash is
+// sha256("sourceLang|targetLang|text") hex-encoded; the language-pair
+// prefix lets implementations such as BoltCache shard storage per pair
+// without re-hashing.
+func CacheKey(sourceLang string, targetLang string, text string) string {
+	sum := sha256.Sum256([]byte(sourceLang + "|" + targetLang + "|" + text))
+	return fmt.Sprintf("%s|%s|%s", sourceLang, targetLang, hex.EncodeToString(sum[:]))
+}
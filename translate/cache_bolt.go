@@ -0,0 +1,111 @@
+package translate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltCache is an on-disk Cache backed by a bbolt file, with one bucket per
+// language pair (e.g. "en->fr") so entries for a pair can be inspected or
+// wiped independently.
+type BoltCache struct {
+	db *bolt.DB
+}
+
+// boltCacheRecord is what gets JSON-encoded into each bbolt value.
+type boltCacheRecord struct {
+	Value     string `json:"value"`
+	ExpiresAt int64  `json:"expires_at,omitempty"` // unix seconds, 0 means never
+}
+
+const boltFallbackBucket = "translations"
+
+// NewBoltCache opens (creating if needed) a bbolt database at path.
+func NewBoltCache(path string) (*BoltCache, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt cache %q: %w", path, err)
+	}
+	return &BoltCache{db: db}, nil
+}
+
+// Close releases the underlying bbolt file handle.
+func (c *BoltCache) Close() error {
+	return c.db.Close()
+}
+
+// boltBucketAndKey splits a CacheKey's "sourceLang|targetLang|hash" format
+// into a bucket name and a bucket-local key.
+func boltBucketAndKey(key string) (bucket string, localKey string) {
+	parts := strings.SplitN(key, "|", 3)
+	if len(parts) != 3 {
+		return boltFallbackBucket, key
+	}
+	return parts[0] + "->" + parts[1], parts[2]
+}
+
+func (c *BoltCache) Get(key string) (string, bool) {
+	bucketName, localKey := boltBucketAndKey(key)
+
+	var record boltCacheRecord
+	var found bool
+	err := c.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(bucketName))
+		if bucket == nil {
+			return nil
+		}
+		raw := bucket.Get([]byte(localKey))
+		if raw == nil {
+			return nil
+		}
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return err
+		}
+		found = true
+		return nil
+	})
+	if err != nil || !found {
+		return "", false
+	}
+
+	if record.ExpiresAt != 0 && time.Now().Unix() > record.ExpiresAt {
+		_ = c.delete(bucketName, localKey)
+		return "", false
+	}
+	return record.Value, true
+}
+
+func (c *BoltCache) Set(key string, value string, ttl time.Duration) {
+	bucketName, localKey := boltBucketAndKey(key)
+
+	record := boltCacheRecord{Value: value}
+	if ttl > 0 {
+		record.ExpiresAt = time.Now().Add(ttl).Unix()
+	}
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	_ = c.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(bucketName))
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(localKey), raw)
+	})
+}
+
+func (c *BoltCache) delete(bucketName string, localKey string) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(bucketName))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.Delete([]byte(localKey))
+	})
+}
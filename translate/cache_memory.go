@@ -0,0 +1,49 @@
+package translate
+
+import (
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// memoryLRUEntry pairs a cached value with its absolute expiry.
+type memoryLRUEntry struct {
+	value     string
+	expiresAt time.Time // zero means "never expires"
+}
+
+// MemoryLRU is an in-process Cache backed by a fixed-size LRU, suitable as
+// a first layer in front of BoltCache or as the only cache for short-lived
+// processes.
+type MemoryLRU struct {
+	cache *lru.Cache[string, memoryLRUEntry]
+}
+
+// NewMemoryLRU builds a MemoryLRU holding at most size entries.
+func NewMemoryLRU(size int) (*MemoryLRU, error) {
+	cache, err := lru.New[string, memoryLRUEntry](size)
+	if err != nil {
+		return nil, err
+	}
+	return &MemoryLRU{cache: cache}, nil
+}
+
+func (m *MemoryLRU) Get(key string) (string, bool) {
+	entry, ok := m.cache.Get(key)
+	if !ok {
+		return "", false
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		m.cache.Remove(key)
+		return "", false
+	}
+	return entry.value, true
+}
+
+func (m *MemoryLRU) Set(key string, value string, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	m.cache.Add(key, memoryLRUEntry{value: value, expiresAt: expiresAt})
+}
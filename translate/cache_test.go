@@ -0,0 +1,118 @@
+package translate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// countingTranslator records every Translate call it receives.
+type countingTranslator struct {
+	calls int
+}
+
+func (c *countingTranslator) Translate(_ context.Context, req TranslationRequest) (DeepLXTranslationResult, error) {
+	c.calls++
+	return DeepLXTranslationResult{
+		Code: 200,
+		Data: fmt.Sprintf("translated:%s", req.Texts[0]),
+	}, nil
+}
+
+// detectingTranslator fakes an auto-detecting backend: whatever SourceLang
+// it's called with, it reports back a fixed detected language instead of
+// echoing the request, simulating GoogleProvider resolving "auto".
+type detectingTranslator struct {
+	detected string
+}
+
+func (d *detectingTranslator) Translate(_ context.Context, req TranslationRequest) (DeepLXTranslationResult, error) {
+	return DeepLXTranslationResult{
+		Code:       200,
+		Data:       fmt.Sprintf("translated:%s", req.Texts[0]),
+		SourceLang: d.detected,
+	}, nil
+}
+
+func (d *detectingTranslator) Name() string                      { return "detecting" }
+func (d *detectingTranslator) SupportedLanguages() []string      { return nil }
+func (d *detectingTranslator) HealthCheck(context.Context) error { return nil }
+
+func (c *countingTranslator) Name() string                      { return "counting" }
+func (c *countingTranslator) SupportedLanguages() []string      { return nil }
+func (c *countingTranslator) HealthCheck(context.Context) error { return nil }
+
+func TestCachingTranslatorStitchesHitsAndMissesInOrder(t *testing.T) {
+	lru, err := NewMemoryLRU(16)
+	if err != nil {
+		t.Fatalf("NewMemoryLRU: %v", err)
+	}
+	inner := &countingTranslator{}
+	cached := NewCachingTranslator(inner, lru, time.Minute)
+
+	lru.Set(CacheKey("en", "fr", "hello"), "bonjour", time.Minute)
+
+	result, err := cached.Translate(context.Background(), TranslationRequest{
+		SourceLang: "en",
+		TargetLang: "fr",
+		Texts:      []string{"hello", "world"},
+	})
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+
+	want := "bonjour\ntranslated:world"
+	if result.Data != want {
+		t.Fatalf("expected Data %q, got %q", want, result.Data)
+	}
+	if inner.calls != 1 {
+		t.Fatalf("expected exactly 1 call to the inner translator, got %d", inner.calls)
+	}
+
+	metrics := cached.Metrics()
+	if metrics.Hits != 1 || metrics.Misses != 1 {
+		t.Fatalf("expected 1 hit and 1 miss, got %+v", metrics)
+	}
+}
+
+// TestCachingTranslatorPropagatesDetectedSourceLang guards against
+// CachingTranslator echoing back req.SourceLang ("auto") instead of the
+// language Inner actually detected on a cache miss.
+func TestCachingTranslatorPropagatesDetectedSourceLang(t *testing.T) {
+	lru, err := NewMemoryLRU(16)
+	if err != nil {
+		t.Fatalf("NewMemoryLRU: %v", err)
+	}
+	inner := &detectingTranslator{detected: "fr"}
+	cached := NewCachingTranslator(inner, lru, time.Minute)
+
+	result, err := cached.Translate(context.Background(), TranslationRequest{
+		SourceLang: "auto",
+		TargetLang: "en",
+		Texts:      []string{"bonjour"},
+	})
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if result.SourceLang != "fr" {
+		t.Fatalf("expected detected SourceLang %q, got %q", "fr", result.SourceLang)
+	}
+
+	// A second call for the same text should hit the cache and still report
+	// the detected language, not "auto".
+	result, err = cached.Translate(context.Background(), TranslationRequest{
+		SourceLang: "auto",
+		TargetLang: "en",
+		Texts:      []string{"bonjour"},
+	})
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if result.SourceLang != "fr" {
+		t.Fatalf("expected cached SourceLang %q, got %q", "fr", result.SourceLang)
+	}
+	if cached.Metrics().Hits != 1 {
+		t.Fatalf("expected the second call to be a cache hit, got metrics %+v", cached.Metrics())
+	}
+}
@@ -0,0 +1,145 @@
+package translate
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// CacheMetrics is a point-in-time snapshot of a CachingTranslator's
+// hit/miss counters.
+type CacheMetrics struct {
+	Hits       int64
+	Misses     int64
+	BytesSaved int64 // bytes of source text that didn't need re-translating
+}
+
+// CachingTranslator wraps another Translator with a Cache keyed by
+// (sourceLang, targetLang, text), so repeated lookups don't re-hit a paid
+// backend. Misses are forwarded to Inner one text at a time so caching
+// works regardless of whether Inner supports multi-text batches.
+type CachingTranslator struct {
+	Inner Translator
+	Cache Cache
+	TTL   time.Duration
+
+	hits       int64
+	misses     int64
+	bytesSaved int64
+}
+
+// NewCachingTranslator wraps inner with cache, caching entries for ttl (0
+// meaning entries never expire).
+func NewCachingTranslator(inner Translator, cache Cache, ttl time.Duration) *CachingTranslator {
+	return &CachingTranslator{Inner: inner, Cache: cache, TTL: ttl}
+}
+
+func (c *CachingTranslator) Translate(ctx context.Context, req TranslationRequest) (DeepLXTranslationResult, error) {
+	results := make([]string, len(req.Texts))
+	sourceLangs := make([]string, len(req.Texts))
+	missing := make([]int, 0, len(req.Texts))
+
+	for i, text := range req.Texts {
+		key := CacheKey(req.SourceLang, req.TargetLang, text)
+		if cached, ok := c.Cache.Get(key); ok {
+			lang, translated := decodeCachedTranslation(cached)
+			results[i] = translated
+			sourceLangs[i] = lang
+			atomic.AddInt64(&c.hits, 1)
+			atomic.AddInt64(&c.bytesSaved, int64(len(text)))
+			continue
+		}
+		missing = append(missing, i)
+	}
+
+	for _, i := range missing {
+		text := req.Texts[i]
+		result, err := c.Inner.Translate(ctx, TranslationRequest{
+			SourceLang: req.SourceLang,
+			TargetLang: req.TargetLang,
+			Texts:      []string{text},
+			ProxyURL:   req.ProxyURL,
+		})
+		atomic.AddInt64(&c.misses, 1)
+		if err != nil {
+			return result, err
+		}
+		if result.Code != 200 {
+			return result, nil
+		}
+
+		results[i] = result.Data
+		sourceLangs[i] = result.SourceLang
+		c.Cache.Set(CacheKey(req.SourceLang, req.TargetLang, text), encodeCachedTranslation(result.SourceLang, result.Data), c.TTL)
+	}
+
+	return DeepLXTranslationResult{
+		Code:         200,
+		Message:      "Success",
+		Data:         strings.Join(results, "\n"),
+		Translations: results,
+		SourceLang:   aggregateSourceLang(req.SourceLang, sourceLangs),
+		TargetLang:   req.TargetLang,
+		Method:       "Cached:" + c.Inner.Name(),
+	}, nil
+}
+
+// cachedTranslationSep separates the detected source language from the
+// translated text in a cache entry; it's a control character unlikely to
+// appear in either.
+const cachedTranslationSep = "\x1f"
+
+func encodeCachedTranslation(sourceLang string, translated string) string {
+	return sourceLang + cachedTranslationSep + translated
+}
+
+// decodeCachedTranslation splits a cache entry back into its detected
+// source language and translated text. Entries written before this
+// encoding existed have no separator; they're treated as having an unknown
+// source language.
+func decodeCachedTranslation(cached string) (sourceLang string, translated string) {
+	lang, text, ok := strings.Cut(cached, cachedTranslationSep)
+	if !ok {
+		return "", cached
+	}
+	return lang, text
+}
+
+// aggregateSourceLang picks the SourceLang to report for a batch result.
+// When the caller asked for an explicit language, no detection happened and
+// that language is authoritative. Otherwise ("" or "auto"), it reports the
+// first detected language among perText, since CachingTranslator has no
+// single well-defined answer for a batch that mixes languages.
+func aggregateSourceLang(requested string, perText []string) string {
+	if requested != "" && requested != "auto" {
+		return requested
+	}
+	for _, lang := range perText {
+		if lang != "" {
+			return lang
+		}
+	}
+	return requested
+}
+
+func (c *CachingTranslator) Name() string {
+	return "cached:" + c.Inner.Name()
+}
+
+func (c *CachingTranslator) SupportedLanguages() []string {
+	return c.Inner.SupportedLanguages()
+}
+
+func (c *CachingTranslator) HealthCheck(ctx context.Context) error {
+	return c.Inner.HealthCheck(ctx)
+}
+
+// Metrics returns a snapshot of the cache's hit/miss counters.
+func (c *CachingTranslator) Metrics() CacheMetrics {
+	return CacheMetrics{
+		Hits:       atomic.LoadInt64(&c.hits),
+		Misses:     atomic.LoadInt64(&c.misses),
+		BytesSaved: atomic.LoadInt64(&c.bytesSaved),
+	}
+}
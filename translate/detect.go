@@ -0,0 +1,151 @@
+package translate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pemistahl/lingua-go"
+)
+
+// DetectOptions configures a call to DetectLanguage. APIKey selects the
+// Google Cloud Translation V2 detect endpoint; when it is empty, detection
+// falls back to a local, offline model.
+type DetectOptions struct {
+	APIKey   string
+	ProxyURL string
+}
+
+// DetectionResult is the outcome of a language detection call.
+type DetectionResult struct {
+	Language   string
+	Confidence float64 // 0..1
+	IsReliable bool
+}
+
+// detectConfidenceThreshold is the minimum confidence for IsReliable, chosen
+// to match Google Cloud Translate's own "reliable" cutoff for the v2 API.
+const detectConfidenceThreshold = 0.5
+
+// DetectLanguage identifies the language of text. With opts.APIKey set, it
+// calls Google Cloud Translation V2's /detect endpoint; otherwise it falls
+// back to a local model (github.com/pemistahl/lingua-go) so detection still
+// works with no API key configured.
+func DetectLanguage(text string, opts DetectOptions) (DetectionResult, error) {
+	if opts.APIKey == "" {
+		return detectLanguageLocal(text)
+	}
+	return detectLanguageGoogle(text, opts.APIKey, opts.ProxyURL)
+}
+
+type googleDetectRequest struct {
+	Q string `json:"q"`
+}
+
+type googleDetectResponse struct {
+	Data struct {
+		Detections [][]struct {
+			Language   string  `json:"language"`
+			Confidence float64 `json:"confidence"`
+			IsReliable bool    `json:"isReliable"`
+		} `json:"detections"`
+	} `json:"data"`
+}
+
+// googleDetectBaseURL is a var, not a const, so tests can point it at a
+// local httptest server instead of the real Google endpoint.
+var googleDetectBaseURL = "https://translation.googleapis.com/language/translate/v2/detect"
+
+func detectLanguageGoogle(text string, apiKey string, proxyURL string) (DetectionResult, error) {
+	jsonData, err := json.Marshal(googleDetectRequest{Q: text})
+	if err != nil {
+		return DetectionResult{}, fmt.Errorf("failed to marshal detect request: %w", err)
+	}
+
+	fullURL := fmt.Sprintf("%s?key=%s", googleDetectBaseURL, apiKey)
+	request, err := http.NewRequest("POST", fullURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return DetectionResult{}, fmt.Errorf("failed to create detect request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	client, reportProxyResult, err := buildHTTPClient(proxyURL, nil)
+	if err != nil {
+		return DetectionResult{}, err
+	}
+
+	resp, err := client.Do(request)
+	reportProxyResult(err)
+	if err != nil {
+		log.Printf("Detect request error: %v\n", err)
+		return DetectionResult{}, fmt.Errorf("detect request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return DetectionResult{}, fmt.Errorf("failed to read detect response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return DetectionResult{}, fmt.Errorf("detect API error: %s", string(body))
+	}
+
+	var response googleDetectResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return DetectionResult{}, fmt.Errorf("failed to parse detect response: %w", err)
+	}
+	if len(response.Data.Detections) == 0 || len(response.Data.Detections[0]) == 0 {
+		return DetectionResult{}, fmt.Errorf("detect API returned no detections")
+	}
+
+	best := response.Data.Detections[0][0]
+	return DetectionResult{
+		Language:   best.Language,
+		Confidence: best.Confidence,
+		IsReliable: best.IsReliable,
+	}, nil
+}
+
+var (
+	localDetectorOnce sync.Once
+	localDetector     lingua.LanguageDetector
+)
+
+func getLocalDetector() lingua.LanguageDetector {
+	localDetectorOnce.Do(func() {
+		localDetector = lingua.NewLanguageDetectorBuilder().
+			FromAllLanguages().
+			WithPreloadedLanguageModels().
+			Build()
+	})
+	return localDetector
+}
+
+// localLanguageCode maps lingua-go's ISO639-1 codes to the lowercase codes
+// Google Translate expects (they already match for every language lingua
+// supports, but this keeps the seam explicit for future exceptions).
+func localLanguageCode(lang lingua.Language) string {
+	return strings.ToLower(lang.IsoCode639_1().String())
+}
+
+func detectLanguageLocal(text string) (DetectionResult, error) {
+	detector := getLocalDetector()
+
+	confidenceValues := detector.ComputeLanguageConfidenceValues(text)
+	if len(confidenceValues) == 0 {
+		return DetectionResult{}, fmt.Errorf("local detector could not identify a language")
+	}
+
+	best := confidenceValues[0]
+	code := localLanguageCode(best.Language())
+	return DetectionResult{
+		Language:   code,
+		Confidence: best.Value(),
+		IsReliable: best.Value() >= detectConfidenceThreshold,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package translate
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDetectLanguageGoogleParsesBestDetection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"detections":[[{"language":"fr","confidence":0.87,"isReliable":true}]]}}`))
+	}))
+	defer server.Close()
+
+	original := googleDetectBaseURL
+	googleDetectBaseURL = server.URL
+	defer func() { googleDetectBaseURL = original }()
+
+	result, err := detectLanguageGoogle("bonjour", "test-key", "")
+	if err != nil {
+		t.Fatalf("detectLanguageGoogle returned error: %v", err)
+	}
+	if result.Language != "fr" || result.Confidence != 0.87 || !result.IsReliable {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestDetectLanguageGoogleErrorsOnEmptyDetections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"detections":[]}}`))
+	}))
+	defer server.Close()
+
+	original := googleDetectBaseURL
+	googleDetectBaseURL = server.URL
+	defer func() { googleDetectBaseURL = original }()
+
+	if _, err := detectLanguageGoogle("text", "test-key", ""); err == nil {
+		t.Fatal("expected an error when the API returns no detections")
+	}
+}
+
+func TestDetectLanguageGoogleErrorsOnNon200(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":"bad key"}`))
+	}))
+	defer server.Close()
+
+	original := googleDetectBaseURL
+	googleDetectBaseURL = server.URL
+	defer func() { googleDetectBaseURL = original }()
+
+	if _, err := detectLanguageGoogle("text", "test-key", ""); err == nil {
+		t.Fatal("expected an error on a non-200 response")
+	}
+}
+
+func TestDetectLanguageDispatchesOnAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"detections":[[{"language":"de","confidence":0.9,"isReliable":true}]]}}`))
+	}))
+	defer server.Close()
+
+	original := googleDetectBaseURL
+	googleDetectBaseURL = server.URL
+	defer func() { googleDetectBaseURL = original }()
+
+	result, err := DetectLanguage("hallo", DetectOptions{APIKey: "test-key"})
+	if err != nil {
+		t.Fatalf("DetectLanguage returned error: %v", err)
+	}
+	if result.Language != "de" {
+		t.Fatalf("expected DetectLanguage to use the Google path when APIKey is set, got %+v", result)
+	}
+}
+
+func TestDetectLanguageFallsBackToLocalWithoutAPIKey(t *testing.T) {
+	result, err := DetectLanguage("This is clearly English text for detection.", DetectOptions{})
+	if err != nil {
+		t.Fatalf("DetectLanguage returned error: %v", err)
+	}
+	if result.Language != "en" {
+		t.Fatalf("expected local detector to identify English, got %+v", result)
+	}
+}
+
+func TestDetectLanguageGoogleRequestBodyCarriesText(t *testing.T) {
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.Write([]byte(`{"data":{"detections":[[{"language":"es","confidence":0.6,"isReliable":true}]]}}`))
+	}))
+	defer server.Close()
+
+	original := googleDetectBaseURL
+	googleDetectBaseURL = server.URL
+	defer func() { googleDetectBaseURL = original }()
+
+	if _, err := detectLanguageGoogle("hola", "test-key", ""); err != nil {
+		t.Fatalf("detectLanguageGoogle returned error: %v", err)
+	}
+	if string(receivedBody) != `{"q":"hola"}` {
+		t.Fatalf("unexpected request body: %s", receivedBody)
+	}
+}
@@ -2,21 +2,21 @@ package translate
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	// "net/http/httputil"
-	"net/url"
 )
 
 // GoogleTranslateRequest represents the request body for Google Cloud Translation API
 type GoogleTranslateRequest struct {
 	Q      []string `json:"q"`      // Array of texts to translate
-	Source string   `json:"source"`  // Source language
-	Target string   `json:"target"`  // Target language
-	Format string   `json:"format"`  // Text format
+	Source string   `json:"source"` // Source language
+	Target string   `json:"target"` // Target language
+	Format string   `json:"format"` // Text format
 }
 
 // GoogleTranslateItem represents a single translation result from Google
@@ -31,8 +31,31 @@ type GoogleTranslateResponse struct {
 	} `json:"data"`
 }
 
-// TranslateByGoogle translates texts using Google Cloud Translation API V2
-func TranslateByGoogle(sourceLang string, targetLang string, texts []string, apiKey string, proxyURL string) (DeepLXTranslationResult, error) {
+// TranslateByGoogle translates texts using the Google Cloud Translation API.
+// credential may be either a plain V2 API key or the raw JSON of a
+// service-account key; in the latter case the request is dispatched to
+// TranslateByGoogleV3 using the project embedded in the key and sane
+// defaults (Location "global", no glossary, no model override). The V2
+// path below is kept as the legacy, API-key-only behaviour.
+func TranslateByGoogle(ctx context.Context, sourceLang string, targetLang string, texts []string, credential string, proxyURL string) (DeepLXTranslationResult, error) {
+	if projectID, ok := serviceAccountProjectID(credential); ok {
+		// V3 only auto-detects when sourceLanguageCode is omitted, not when
+		// it's given the literal string "auto"; normalize so the "" or
+		// "auto" contract TranslationRequest documents holds for V3 too.
+		if sourceLang == "auto" {
+			sourceLang = ""
+		}
+		return TranslateByGoogleV3(ctx, sourceLang, targetLang, texts, []byte(credential), GoogleV3Options{
+			ProjectID: projectID,
+			Location:  "global",
+		}, proxyURL)
+	}
+	return translateByGoogleV2(ctx, sourceLang, targetLang, texts, credential, proxyURL)
+}
+
+// translateByGoogleV2 is the legacy Google Cloud Translation API V2 path,
+// authenticated with a simple "?key=" API key.
+func translateByGoogleV2(ctx context.Context, sourceLang string, targetLang string, texts []string, apiKey string, proxyURL string) (DeepLXTranslationResult, error) {
 	// Parameter validation
 	if len(texts) == 0 {
 		return DeepLXTranslationResult{
@@ -48,14 +71,64 @@ func TranslateByGoogle(sourceLang string, targetLang string, texts []string, api
 		}, nil
 	}
 
-	// Set default languages if not specified
-	if sourceLang == "" {
-		sourceLang = "en"
+	// An empty or "auto" source means the caller wants auto-detection, not
+	// a silent default, matching Google Cloud Translate's own v2/v3
+	// semantics.
+	if sourceLang == "" || sourceLang == "auto" {
+		detection, err := DetectLanguage(texts[0], DetectOptions{APIKey: apiKey, ProxyURL: proxyURL})
+		if err != nil {
+			log.Printf("Language detection failed: %v\n", err)
+			return DeepLXTranslationResult{
+				Code:    http.StatusServiceUnavailable,
+				Message: fmt.Sprintf("Language detection failed: %v", err),
+			}, err
+		}
+		sourceLang = detection.Language
 	}
 	if targetLang == "" {
 		targetLang = "en"
 	}
 
+	translations, statusCode, err := googleV2RawTranslate(ctx, sourceLang, targetLang, texts, apiKey, proxyURL)
+	if err != nil {
+		return DeepLXTranslationResult{
+			Code:    statusCode,
+			Message: err.Error(),
+		}, err
+	}
+	if statusCode != http.StatusOK {
+		return DeepLXTranslationResult{
+			Code:    statusCode,
+			Message: "Translation failed, API returns an empty result",
+		}, nil
+	}
+
+	// Return successful result. Data holds the first (and, for the common
+	// single-text call, only) translation; Translations holds one entry
+	// per input text so batch callers can recover the per-text boundaries
+	// that joining with a separator would lose.
+	return DeepLXTranslationResult{
+		Code:         http.StatusOK,
+		Message:      "Success",
+		Data:         translations[0],
+		Translations: translations,
+		SourceLang:   sourceLang,
+		TargetLang:   targetLang,
+		Method:       "GoogleCloud",
+	}, nil
+}
+
+// googleV2BaseURL is a var, not a const, so tests can point it at a local
+// httptest server instead of the real Google endpoint.
+var googleV2BaseURL = "https://translation.googleapis.com/language/translate/v2"
+
+// googleV2RawTranslate performs a single, unretried Google Cloud Translation
+// V2 request for texts and returns the translated text for every input, in
+// order, alongside the HTTP status code so callers (TranslateStream in
+// particular) can decide whether to retry. ctx bounds both connection setup
+// and the in-flight request, so a cancelled ctx aborts it immediately rather
+// than only between retries.
+func googleV2RawTranslate(ctx context.Context, sourceLang string, targetLang string, texts []string, apiKey string, proxyURL string) ([]string, int, error) {
 	// Prepare request body
 	requestBody := GoogleTranslateRequest{
 		Q:      texts,
@@ -67,26 +140,19 @@ func TranslateByGoogle(sourceLang string, targetLang string, texts []string, api
 	// Marshal request body to JSON
 	jsonData, err := json.Marshal(requestBody)
 	if err != nil {
-		return DeepLXTranslationResult{
-			Code:    http.StatusInternalServerError,
-			Message: "Failed to marshal request",
-		}, err
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	// log.Printf("Request Body: %s\n", string(jsonData))
 
 	// Build URL with API key
-	baseURL := "https://translation.googleapis.com/language/translate/v2"
-	fullURL := fmt.Sprintf("%s?key=%s", baseURL, apiKey)
+	fullURL := fmt.Sprintf("%s?key=%s", googleV2BaseURL, apiKey)
 	// log.Printf("Request URL: %s\n", fullURL)
 
 	// Create HTTP request
-	request, err := http.NewRequest("POST", fullURL, bytes.NewBuffer(jsonData))
+	request, err := http.NewRequestWithContext(ctx, "POST", fullURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return DeepLXTranslationResult{
-			Code:    http.StatusServiceUnavailable,
-			Message: "Failed to create request",
-		}, err
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set request headers
@@ -101,40 +167,20 @@ func TranslateByGoogle(sourceLang string, targetLang string, texts []string, api
 	// 	log.Printf("Full Request:\n%s\n", string(requestDump))
 	// }
 
-	// Configure HTTP client with proxy if specified
-	var client *http.Client
-	if proxyURL != "" {
-		// Parse proxy URL
-		proxy, err := url.Parse(proxyURL)
-		if err != nil {
-			log.Printf("Failed to parse proxy URL: %v\n", err)
-			return DeepLXTranslationResult{
-				Code:    http.StatusServiceUnavailable,
-				Message: fmt.Sprintf("Invalid proxy URL: %v", err),
-			}, err
-		}
-
-		// Create transport with proxy
-		transport := &http.Transport{
-			Proxy: http.ProxyURL(proxy),
-		}
-
-		// Create client with custom transport
-		client = &http.Client{Transport: transport}
-		log.Printf("Using proxy: %s\n", proxyURL)
-	} else {
-		client = &http.Client{}
-		log.Println("No proxy specified, using direct connection")
+	// Configure HTTP client with proxy if specified, falling back to
+	// DefaultProxyProvider, then a direct connection.
+	client, reportProxyResult, err := buildHTTPClient(proxyURL, nil)
+	if err != nil {
+		log.Printf("Failed to configure proxy: %v\n", err)
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("invalid proxy configuration: %w", err)
 	}
 
 	// Send request
 	resp, err := client.Do(request)
+	reportProxyResult(err)
 	if err != nil {
 		log.Printf("Request error: %v\n", err)
-		return DeepLXTranslationResult{
-			Code:    http.StatusServiceUnavailable,
-			Message: fmt.Sprintf("Translation request failed: %v", err),
-		}, err
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("translation request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -154,10 +200,7 @@ func TranslateByGoogle(sourceLang string, targetLang string, texts []string, api
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		log.Printf("Failed to read response body: %v\n", err)
-		return DeepLXTranslationResult{
-			Code:    http.StatusServiceUnavailable,
-			Message: fmt.Sprintf("Failed to read response: %v", err),
-		}, err
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	// log.Printf("Response Body: %s\n", string(body))
@@ -165,10 +208,7 @@ func TranslateByGoogle(sourceLang string, targetLang string, texts []string, api
 	// Check HTTP status code
 	if resp.StatusCode != http.StatusOK {
 		log.Printf("API returned non-200 status code: %d\n", resp.StatusCode)
-		return DeepLXTranslationResult{
-			Code:    resp.StatusCode,
-			Message: fmt.Sprintf("API error: %s", string(body)),
-		}, nil
+		return nil, resp.StatusCode, fmt.Errorf("API error: %s", string(body))
 	}
 
 	// Parse response
@@ -176,32 +216,18 @@ func TranslateByGoogle(sourceLang string, targetLang string, texts []string, api
 	err = json.Unmarshal(body, &response)
 	if err != nil {
 		log.Printf("Failed to parse response: %v\n", err)
-		return DeepLXTranslationResult{
-			Code:    http.StatusServiceUnavailable,
-			Message: fmt.Sprintf("Failed to parse response: %v", err),
-		}, err
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	// Extract translation result
+	// Extract translation results
 	if len(response.Data.Translations) == 0 {
 		log.Println("API returned empty translations array")
-		return DeepLXTranslationResult{
-			Code:    http.StatusServiceUnavailable,
-			Message: "Translation failed, API returns an empty result",
-		}, nil
+		return nil, http.StatusServiceUnavailable, nil
 	}
 
-	// Get the first translation result
-	translatedText := response.Data.Translations[0].TranslatedText
-	// log.Printf("Translated text: %s\n", translatedText)
-
-	// Return successful result
-	return DeepLXTranslationResult{
-		Code:       http.StatusOK,
-		Message:    "Success",
-		Data:       translatedText,
-		SourceLang: sourceLang,
-		TargetLang: targetLang,
-		Method:     "GoogleCloud",
-	}, nil
-}
\ No newline at end of file
+	translations := make([]string, len(response.Data.Translations))
+	for i, item := range response.Data.Translations {
+		translations[i] = item.TranslatedText
+	}
+	return translations, http.StatusOK, nil
+}
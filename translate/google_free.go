@@ -0,0 +1,217 @@
+package translate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// googleFreeUserAgentEnv lets operators override the User-Agent sent to the
+// free endpoint, e.g. when the default starts getting blocked.
+const googleFreeUserAgentEnv = "GOOGLE_FREE_USER_AGENT"
+
+const googleFreeDefaultUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+
+// googleFreeBaseURL is a var, not a const, so tests can point it at a local
+// httptest server instead of the real endpoint.
+var googleFreeBaseURL = "https://translate.googleapis.com/translate_a/single"
+
+// googleFreeRateLimiter is a simple per-host limiter shared by every call to
+// TranslateByGoogleFree: each request waits until interval has passed since
+// the last recorded response, where interval starts at minInterval, doubles
+// on 429/503 up to maxInterval, and resets on success; the backoff after a
+// 429/503 adds jitter to avoid thundering herds across goroutines. wait()
+// does not reserve a slot, so concurrent callers that wait at the same time
+// may send their requests together.
+type googleFreeRateLimiter struct {
+	mu          sync.Mutex
+	minInterval time.Duration
+	maxInterval time.Duration
+	interval    time.Duration
+	lastRequest time.Time
+}
+
+var googleFreeLimiter = &googleFreeRateLimiter{
+	minInterval: 200 * time.Millisecond,
+	maxInterval: 30 * time.Second,
+	interval:    200 * time.Millisecond,
+}
+
+func (l *googleFreeRateLimiter) wait() {
+	l.mu.Lock()
+	wait := time.Until(l.lastRequest.Add(l.interval))
+	l.mu.Unlock()
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+func (l *googleFreeRateLimiter) recordSuccess() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastRequest = time.Now()
+	l.interval = l.minInterval
+}
+
+func (l *googleFreeRateLimiter) recordThrottled() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastRequest = time.Now()
+	l.interval *= 2
+	if l.interval > l.maxInterval {
+		l.interval = l.maxInterval
+	}
+	jitter := time.Duration(rand.Int63n(int64(l.interval) / 2))
+	return l.interval + jitter
+}
+
+// TranslateByGoogleFree translates texts without an API key by calling the
+// public endpoint behind translate.google.com. It is a zero-config fallback
+// for when no Google Cloud credential is configured; expect it to be less
+// reliable and more rate-limited than TranslateByGoogle.
+func TranslateByGoogleFree(sourceLang string, targetLang string, texts []string, proxyURL string) (DeepLXTranslationResult, error) {
+	if len(texts) == 0 {
+		return DeepLXTranslationResult{
+			Code:    http.StatusBadRequest,
+			Message: "No text to translate",
+		}, nil
+	}
+
+	if sourceLang == "" {
+		sourceLang = "auto"
+	}
+	if targetLang == "" {
+		targetLang = "en"
+	}
+
+	client, reportProxyResult, err := buildHTTPClient(proxyURL, nil)
+	if err != nil {
+		return DeepLXTranslationResult{
+			Code:    http.StatusServiceUnavailable,
+			Message: fmt.Sprintf("Invalid proxy configuration: %v", err),
+		}, err
+	}
+
+	results := make([]string, len(texts))
+	for i, text := range texts {
+		translated, err := translateByGoogleFreeOne(client, reportProxyResult, sourceLang, targetLang, text)
+		if err != nil {
+			return DeepLXTranslationResult{
+				Code:    http.StatusServiceUnavailable,
+				Message: err.Error(),
+			}, err
+		}
+		results[i] = translated
+	}
+
+	return DeepLXTranslationResult{
+		Code:         http.StatusOK,
+		Message:      "Success",
+		Data:         strings.Join(results, "\n"),
+		Translations: results,
+		SourceLang:   sourceLang,
+		TargetLang:   targetLang,
+		Method:       "GoogleFree",
+	}, nil
+}
+
+func translateByGoogleFreeOne(client *http.Client, reportProxyResult func(error), sourceLang string, targetLang string, text string) (string, error) {
+	const maxAttempts = 5
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		googleFreeLimiter.wait()
+
+		params := url.Values{}
+		params.Set("client", "gtx")
+		params.Set("sl", sourceLang)
+		params.Set("tl", targetLang)
+		params.Set("dt", "t")
+		params.Set("q", text)
+
+		fullURL := googleFreeBaseURL + "?" + params.Encode()
+		request, err := http.NewRequest("GET", fullURL, nil)
+		if err != nil {
+			return "", err
+		}
+		request.Header.Set("User-Agent", googleFreeUserAgent())
+
+		resp, err := client.Do(request)
+		reportProxyResult(err)
+		if err != nil {
+			log.Printf("GoogleFree request error: %v\n", err)
+			return "", fmt.Errorf("translation request failed: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			resp.Body.Close()
+			backoff := googleFreeLimiter.recordThrottled()
+			log.Printf("GoogleFree rate limited (status %d), retrying in %s\n", resp.StatusCode, backoff)
+			time.Sleep(backoff)
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to read response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("GoogleFree API error (status %d): %s", resp.StatusCode, string(body))
+		}
+
+		googleFreeLimiter.recordSuccess()
+		return parseGoogleFreeResponse(body)
+	}
+
+	return "", fmt.Errorf("GoogleFree: gave up after %d attempts due to rate limiting", maxAttempts)
+}
+
+// parseGoogleFreeResponse extracts the translated text out of the nested
+// JSON array the free endpoint returns, e.g. [[["hi","hello",null,null,1]]].
+func parseGoogleFreeResponse(body []byte) (string, error) {
+	var raw []interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(raw) == 0 {
+		return "", fmt.Errorf("empty response from GoogleFree")
+	}
+
+	segments, ok := raw[0].([]interface{})
+	if !ok {
+		return "", fmt.Errorf("unexpected response shape from GoogleFree")
+	}
+
+	var sb strings.Builder
+	for _, seg := range segments {
+		pair, ok := seg.([]interface{})
+		if !ok || len(pair) == 0 {
+			continue
+		}
+		piece, ok := pair[0].(string)
+		if !ok {
+			continue
+		}
+		sb.WriteString(piece)
+	}
+
+	if sb.Len() == 0 {
+		return "", fmt.Errorf("no translated segments in GoogleFree response")
+	}
+	return sb.String(), nil
+}
+
+func googleFreeUserAgent() string {
+	if ua := os.Getenv(googleFreeUserAgentEnv); ua != "" {
+		return ua
+	}
+	return googleFreeDefaultUserAgent
+}
@@ -0,0 +1,46 @@
+package translate
+
+import (
+	"context"
+	"net/http"
+)
+
+// GoogleFreeProvider adapts TranslateByGoogleFree to the Translator
+// interface, so it can be registered in a Router as a no-API-key fallback.
+type GoogleFreeProvider struct {
+	ProxyURL string
+}
+
+// NewGoogleFreeProvider builds a Translator backed by the free, unofficial
+// translate.google.com endpoint.
+func NewGoogleFreeProvider(proxyURL string) *GoogleFreeProvider {
+	return &GoogleFreeProvider{ProxyURL: proxyURL}
+}
+
+func (p *GoogleFreeProvider) Translate(_ context.Context, req TranslationRequest) (DeepLXTranslationResult, error) {
+	return TranslateByGoogleFree(req.SourceLang, req.TargetLang, req.Texts, req.ProxyURL)
+}
+
+func (p *GoogleFreeProvider) Name() string {
+	return "google-free"
+}
+
+func (p *GoogleFreeProvider) SupportedLanguages() []string {
+	return nil
+}
+
+func (p *GoogleFreeProvider) HealthCheck(ctx context.Context) error {
+	result, err := p.Translate(ctx, TranslationRequest{
+		SourceLang: "en",
+		TargetLang: "en",
+		Texts:      []string{"ok"},
+		ProxyURL:   p.ProxyURL,
+	})
+	if err != nil {
+		return err
+	}
+	if result.Code != http.StatusOK {
+		return &providerError{provider: p.Name(), code: result.Code, message: result.Message}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package translate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseGoogleFreeResponseJoinsSegments(t *testing.T) {
+	body := []byte(`[[["hello ","bonjour ",null,null,1],["world","monde",null,null,1]]]`)
+	got, err := parseGoogleFreeResponse(body)
+	if err != nil {
+		t.Fatalf("parseGoogleFreeResponse returned error: %v", err)
+	}
+	want := "hello world"
+	if got != want {
+		t.Fatalf("parseGoogleFreeResponse = %q, want %q", got, want)
+	}
+}
+
+func TestParseGoogleFreeResponseRejectsEmptyOrMalformedPayloads(t *testing.T) {
+	for _, body := range [][]byte{
+		[]byte(`[]`),
+		[]byte(`[[]]`),
+		[]byte(`not json`),
+		[]byte(`[{"unexpected":"shape"}]`),
+	} {
+		if _, err := parseGoogleFreeResponse(body); err == nil {
+			t.Errorf("expected an error for payload %s", body)
+		}
+	}
+}
+
+// withFastGoogleFreeLimiter resets the shared rate limiter to near-zero
+// backoff for the duration of a test, restoring it afterward so tests don't
+// bleed throttling state into each other.
+func withFastGoogleFreeLimiter(t *testing.T) {
+	t.Helper()
+	originalMin := googleFreeLimiter.minInterval
+	originalMax := googleFreeLimiter.maxInterval
+	originalInterval := googleFreeLimiter.interval
+	originalLastRequest := googleFreeLimiter.lastRequest
+
+	googleFreeLimiter.minInterval = time.Millisecond
+	googleFreeLimiter.maxInterval = 10 * time.Millisecond
+	googleFreeLimiter.interval = time.Millisecond
+	googleFreeLimiter.lastRequest = time.Time{}
+
+	t.Cleanup(func() {
+		googleFreeLimiter.minInterval = originalMin
+		googleFreeLimiter.maxInterval = originalMax
+		googleFreeLimiter.interval = originalInterval
+		googleFreeLimiter.lastRequest = originalLastRequest
+	})
+}
+
+func TestTranslateByGoogleFreeOneRetriesOn429(t *testing.T) {
+	withFastGoogleFreeLimiter(t)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`[[["bonjour",null,null,null,1]]]`))
+	}))
+	defer server.Close()
+
+	originalBaseURL := googleFreeBaseURL
+	googleFreeBaseURL = server.URL
+	defer func() { googleFreeBaseURL = originalBaseURL }()
+
+	client := server.Client()
+	result, err := translateByGoogleFreeOne(client, func(error) {}, "en", "fr", "hello")
+	if err != nil {
+		t.Fatalf("translateByGoogleFreeOne returned error: %v", err)
+	}
+	if result != "bonjour" {
+		t.Fatalf("result = %q, want %q", result, "bonjour")
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts (2 throttled + 1 success), got %d", attempts)
+	}
+}
+
+func TestTranslateByGoogleFreeOneGivesUpAfterMaxAttempts(t *testing.T) {
+	withFastGoogleFreeLimiter(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	originalBaseURL := googleFreeBaseURL
+	googleFreeBaseURL = server.URL
+	defer func() { googleFreeBaseURL = originalBaseURL }()
+
+	client := server.Client()
+	_, err := translateByGoogleFreeOne(client, func(error) {}, "en", "fr", "hello")
+	if err == nil {
+		t.Fatal("expected an error once retries are exhausted")
+	}
+}
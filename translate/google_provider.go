@@ -0,0 +1,48 @@
+package translate
+
+import (
+	"context"
+	"net/http"
+)
+
+// GoogleProvider adapts TranslateByGoogle to the Translator interface.
+type GoogleProvider struct {
+	APIKey   string
+	ProxyURL string
+}
+
+// NewGoogleProvider builds a Translator backed by TranslateByGoogle: the
+// Google Cloud Translation V2 API for a plain API key, or V3 when apiKey
+// holds a service-account JSON key.
+func NewGoogleProvider(apiKey string, proxyURL string) *GoogleProvider {
+	return &GoogleProvider{APIKey: apiKey, ProxyURL: proxyURL}
+}
+
+func (p *GoogleProvider) Translate(ctx context.Context, req TranslationRequest) (DeepLXTranslationResult, error) {
+	return TranslateByGoogle(ctx, req.SourceLang, req.TargetLang, req.Texts, p.APIKey, req.ProxyURL)
+}
+
+func (p *GoogleProvider) Name() string {
+	return "google"
+}
+
+func (p *GoogleProvider) SupportedLanguages() []string {
+	// Google accepts effectively any BCP-47 code; we don't maintain a list.
+	return nil
+}
+
+func (p *GoogleProvider) HealthCheck(ctx context.Context) error {
+	result, err := p.Translate(ctx, TranslationRequest{
+		SourceLang: "en",
+		TargetLang: "en",
+		Texts:      []string{"ok"},
+		ProxyURL:   p.ProxyURL,
+	})
+	if err != nil {
+		return err
+	}
+	if result.Code != http.StatusOK {
+		return &providerError{provider: p.Name(), code: result.Code, message: result.Message}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestTranslateByGoogleV2MultiLineRoundTrip guards against joining
+// per-text translations with "\n": a translated segment that itself
+// contains a newline must not be confused with a boundary between two
+// input texts.
+func TestTranslateByGoogleV2MultiLineRoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GoogleTranslateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		resp := GoogleTranslateResponse{}
+		for _, q := range req.Q {
+			resp.Data.Translations = append(resp.Data.Translations, GoogleTranslateItem{
+				TranslatedText: "translated:" + q,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	originalBaseURL := googleV2BaseURL
+	googleV2BaseURL = server.URL
+	defer func() { googleV2BaseURL = originalBaseURL }()
+
+	texts := []string{"line one\nline two", "second input"}
+	result, err := translateByGoogleV2(context.Background(), "en", "fr", texts, "test-key", "")
+	if err != nil {
+		t.Fatalf("translateByGoogleV2 returned error: %v", err)
+	}
+	if result.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", result.Code, result.Message)
+	}
+
+	wantTranslations := []string{"translated:line one\nline two", "translated:second input"}
+	if len(result.Translations) != len(wantTranslations) {
+		t.Fatalf("expected %d translations, got %d: %#v", len(wantTranslations), len(result.Translations), result.Translations)
+	}
+	for i, want := range wantTranslations {
+		if result.Translations[i] != want {
+			t.Errorf("Translations[%d] = %q, want %q", i, result.Translations[i], want)
+		}
+	}
+
+	if result.Data != wantTranslations[0] {
+		t.Errorf("Data = %q, want %q", result.Data, wantTranslations[0])
+	}
+}
+
+// TestTranslateByGoogleV2AutoDetectsSourceLanguage guards the dispatch at
+// google.go's "" / "auto" branch: it must call DetectLanguage and use its
+// result as the source language, rather than sending "auto" straight
+// through to the translate endpoint.
+func TestTranslateByGoogleV2AutoDetectsSourceLanguage(t *testing.T) {
+	detectServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"detections":[[{"language":"ja","confidence":0.95,"isReliable":true}]]}}`))
+	}))
+	defer detectServer.Close()
+
+	originalDetectURL := googleDetectBaseURL
+	googleDetectBaseURL = detectServer.URL
+	defer func() { googleDetectBaseURL = originalDetectURL }()
+
+	var gotSource string
+	translateServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GoogleTranslateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		gotSource = req.Source
+		resp := GoogleTranslateResponse{}
+		for _, q := range req.Q {
+			resp.Data.Translations = append(resp.Data.Translations, GoogleTranslateItem{TranslatedText: "translated:" + q})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer translateServer.Close()
+
+	originalTranslateURL := googleV2BaseURL
+	googleV2BaseURL = translateServer.URL
+	defer func() { googleV2BaseURL = originalTranslateURL }()
+
+	result, err := translateByGoogleV2(context.Background(), "auto", "en", []string{"こんにちは"}, "test-key", "")
+	if err != nil {
+		t.Fatalf("translateByGoogleV2 returned error: %v", err)
+	}
+	if gotSource != "ja" {
+		t.Fatalf("expected the translate request to carry the detected source %q, got %q", "ja", gotSource)
+	}
+	if result.SourceLang != "ja" {
+		t.Fatalf("expected result.SourceLang %q, got %q", "ja", result.SourceLang)
+	}
+}
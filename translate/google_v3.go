@@ -0,0 +1,362 @@
+package translate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// GoogleGlossaryConfig selects a pre-uploaded glossary resource for a V3
+// translation request.
+type GoogleGlossaryConfig struct {
+	Glossary   string // Full glossary resource name
+	IgnoreCase bool
+}
+
+// GoogleV3Options configures a call to TranslateByGoogleV3 or
+// BatchTranslateText.
+type GoogleV3Options struct {
+	ProjectID      string
+	Location       string // Defaults to "global" when empty
+	Model          string // e.g. "nmt", or a full AutoML model resource name
+	GlossaryConfig *GoogleGlossaryConfig
+	MimeType       string // "text/plain" (default) or "text/html"
+}
+
+func (o GoogleV3Options) location() string {
+	if o.Location == "" {
+		return "global"
+	}
+	return o.Location
+}
+
+func (o GoogleV3Options) mimeType() string {
+	if o.MimeType == "" {
+		return "text/plain"
+	}
+	return o.MimeType
+}
+
+// googleV3TranslateRequest is the request body for v3's translateText.
+type googleV3TranslateRequest struct {
+	Contents           []string              `json:"contents"`
+	SourceLanguageCode string                `json:"sourceLanguageCode,omitempty"`
+	TargetLanguageCode string                `json:"targetLanguageCode"`
+	MimeType           string                `json:"mimeType"`
+	Model              string                `json:"model,omitempty"`
+	GlossaryConfig     *googleV3GlossaryBody `json:"glossaryConfig,omitempty"`
+}
+
+type googleV3GlossaryBody struct {
+	Glossary   string `json:"glossary"`
+	IgnoreCase bool   `json:"ignoreCase,omitempty"`
+}
+
+type googleV3TranslateResponse struct {
+	Translations []struct {
+		TranslatedText       string `json:"translatedText"`
+		DetectedLanguageCode string `json:"detectedLanguageCode"`
+	} `json:"translations"`
+	GlossaryTranslations []struct {
+		TranslatedText string `json:"translatedText"`
+	} `json:"glossaryTranslations"`
+}
+
+// serviceAccountProjectID reports whether credential is a service-account
+// JSON key and, if so, returns its project_id.
+func serviceAccountProjectID(credential string) (string, bool) {
+	var key struct {
+		Type      string `json:"type"`
+		ProjectID string `json:"project_id"`
+	}
+	if err := json.Unmarshal([]byte(credential), &key); err != nil {
+		return "", false
+	}
+	if key.Type != "service_account" || key.ProjectID == "" {
+		return "", false
+	}
+	return key.ProjectID, true
+}
+
+// buildGoogleV3RequestBody assembles the v3 translateText request body from
+// texts, languages and opts, split out from TranslateByGoogleV3 so the
+// glossary/model wiring can be tested without an OAuth2 round trip.
+func buildGoogleV3RequestBody(texts []string, sourceLang string, targetLang string, opts GoogleV3Options) googleV3TranslateRequest {
+	reqBody := googleV3TranslateRequest{
+		Contents:           texts,
+		SourceLanguageCode: sourceLang,
+		TargetLanguageCode: targetLang,
+		MimeType:           opts.mimeType(),
+		Model:              opts.Model,
+	}
+	if opts.GlossaryConfig != nil {
+		reqBody.GlossaryConfig = &googleV3GlossaryBody{
+			Glossary:   opts.GlossaryConfig.Glossary,
+			IgnoreCase: opts.GlossaryConfig.IgnoreCase,
+		}
+	}
+	return reqBody
+}
+
+func googleV3TokenSource(ctx context.Context, serviceAccountJSON []byte) (oauth2.TokenSource, error) {
+	creds, err := google.CredentialsFromJSON(ctx, serviceAccountJSON, "https://www.googleapis.com/auth/cloud-translation")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse service account JSON: %w", err)
+	}
+	return creds.TokenSource, nil
+}
+
+func googleV3HTTPClient(proxyURL string) (*http.Client, func(error), error) {
+	client, reportProxyResult, err := buildHTTPClient(proxyURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	client.Timeout = 30 * time.Second
+	return client, reportProxyResult, nil
+}
+
+// TranslateByGoogleV3 translates texts using the Cloud Translation V3 API,
+// authenticating with an OAuth2 access token derived from a service-account
+// JSON key rather than the legacy "?key=" query parameter. It supports
+// glossaries and model selection via opts.
+func TranslateByGoogleV3(ctx context.Context, sourceLang string, targetLang string, texts []string, serviceAccountJSON []byte, opts GoogleV3Options, proxyURL string) (DeepLXTranslationResult, error) {
+	if len(texts) == 0 {
+		return DeepLXTranslationResult{
+			Code:    http.StatusBadRequest,
+			Message: "No text to translate",
+		}, nil
+	}
+	if opts.ProjectID == "" {
+		return DeepLXTranslationResult{
+			Code:    http.StatusBadRequest,
+			Message: "ProjectID is required for Cloud Translation V3",
+		}, nil
+	}
+
+	tokenSource, err := googleV3TokenSource(ctx, serviceAccountJSON)
+	if err != nil {
+		return DeepLXTranslationResult{
+			Code:    http.StatusUnauthorized,
+			Message: err.Error(),
+		}, err
+	}
+	token, err := tokenSource.Token()
+	if err != nil {
+		log.Printf("Failed to obtain OAuth2 token: %v\n", err)
+		return DeepLXTranslationResult{
+			Code:    http.StatusUnauthorized,
+			Message: fmt.Sprintf("Failed to obtain OAuth2 token: %v", err),
+		}, err
+	}
+
+	reqBody := buildGoogleV3RequestBody(texts, sourceLang, targetLang, opts)
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return DeepLXTranslationResult{
+			Code:    http.StatusInternalServerError,
+			Message: "Failed to marshal request",
+		}, err
+	}
+
+	fullURL := fmt.Sprintf("https://translation.googleapis.com/v3/projects/%s/locations/%s:translateText", opts.ProjectID, opts.location())
+	request, err := http.NewRequestWithContext(ctx, "POST", fullURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return DeepLXTranslationResult{
+			Code:    http.StatusServiceUnavailable,
+			Message: "Failed to create request",
+		}, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	client, reportProxyResult, err := googleV3HTTPClient(proxyURL)
+	if err != nil {
+		return DeepLXTranslationResult{
+			Code:    http.StatusServiceUnavailable,
+			Message: err.Error(),
+		}, err
+	}
+
+	resp, err := client.Do(request)
+	reportProxyResult(err)
+	if err != nil {
+		log.Printf("Request error: %v\n", err)
+		return DeepLXTranslationResult{
+			Code:    http.StatusServiceUnavailable,
+			Message: fmt.Sprintf("Translation request failed: %v", err),
+		}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return DeepLXTranslationResult{
+			Code:    http.StatusServiceUnavailable,
+			Message: fmt.Sprintf("Failed to read response: %v", err),
+		}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("V3 API returned non-200 status code: %d\n", resp.StatusCode)
+		return DeepLXTranslationResult{
+			Code:    resp.StatusCode,
+			Message: fmt.Sprintf("API error: %s", string(body)),
+		}, nil
+	}
+
+	var response googleV3TranslateResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return DeepLXTranslationResult{
+			Code:    http.StatusServiceUnavailable,
+			Message: fmt.Sprintf("Failed to parse response: %v", err),
+		}, err
+	}
+	if len(response.Translations) == 0 {
+		return DeepLXTranslationResult{
+			Code:    http.StatusServiceUnavailable,
+			Message: "Translation failed, API returns an empty result",
+		}, nil
+	}
+
+	translations := make([]string, len(response.Translations))
+	for i, t := range response.Translations {
+		translations[i] = t.TranslatedText
+	}
+	// With a glossary configured, V3 returns the glossary-applied text in
+	// glossaryTranslations alongside the plain translations.
+	if opts.GlossaryConfig != nil && len(response.GlossaryTranslations) == len(translations) {
+		for i, t := range response.GlossaryTranslations {
+			translations[i] = t.TranslatedText
+		}
+	}
+
+	return DeepLXTranslationResult{
+		Code:         http.StatusOK,
+		Message:      "Success",
+		Data:         translations[0],
+		Translations: translations,
+		SourceLang:   sourceLang,
+		TargetLang:   targetLang,
+		Method:       "GoogleCloudV3",
+	}, nil
+}
+
+// BatchTranslateOperation describes the state of an async V3 batch
+// translation long-running operation.
+type BatchTranslateOperation struct {
+	Name     string // operations/<id>
+	Done     bool
+	Error    string
+	Metadata map[string]interface{}
+}
+
+// BatchTranslateText submits an async batch translation job reading from
+// inputURI and writing to outputURI (both GCS URIs, e.g. "gs://bucket/path"),
+// then polls the resulting operation until it completes or ctx is done.
+func BatchTranslateText(ctx context.Context, opts GoogleV3Options, sourceLang string, targetLangs []string, inputURI string, outputURI string, serviceAccountJSON []byte, proxyURL string) (BatchTranslateOperation, error) {
+	if opts.ProjectID == "" {
+		return BatchTranslateOperation{}, fmt.Errorf("ProjectID is required for Cloud Translation V3")
+	}
+
+	tokenSource, err := googleV3TokenSource(ctx, serviceAccountJSON)
+	if err != nil {
+		return BatchTranslateOperation{}, err
+	}
+	token, err := tokenSource.Token()
+	if err != nil {
+		return BatchTranslateOperation{}, fmt.Errorf("failed to obtain OAuth2 token: %w", err)
+	}
+
+	client, _, err := googleV3HTTPClient(proxyURL)
+	if err != nil {
+		return BatchTranslateOperation{}, err
+	}
+
+	submitURL := fmt.Sprintf("https://translation.googleapis.com/v3/projects/%s/locations/%s:batchTranslateText", opts.ProjectID, opts.location())
+	submitBody, err := json.Marshal(map[string]interface{}{
+		"sourceLanguageCode":  sourceLang,
+		"targetLanguageCodes": targetLangs,
+		"inputConfigs": []map[string]interface{}{
+			{"gcsSource": map[string]string{"inputUri": inputURI}, "mimeType": opts.mimeType()},
+		},
+		"outputConfig": map[string]interface{}{
+			"gcsDestination": map[string]string{"outputUriPrefix": outputURI},
+		},
+	})
+	if err != nil {
+		return BatchTranslateOperation{}, err
+	}
+
+	op, err := doGoogleV3OperationRequest(ctx, client, "POST", submitURL, submitBody, token.AccessToken)
+	if err != nil {
+		return BatchTranslateOperation{}, err
+	}
+
+	pollURL := fmt.Sprintf("https://translation.googleapis.com/v3/%s", op.Name)
+	for !op.Done {
+		select {
+		case <-ctx.Done():
+			return op, ctx.Err()
+		case <-time.After(5 * time.Second):
+		}
+		op, err = doGoogleV3OperationRequest(ctx, client, "GET", pollURL, nil, token.AccessToken)
+		if err != nil {
+			return op, err
+		}
+	}
+	return op, nil
+}
+
+func doGoogleV3OperationRequest(ctx context.Context, client *http.Client, method string, fullURL string, body []byte, accessToken string) (BatchTranslateOperation, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewBuffer(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	if err != nil {
+		return BatchTranslateOperation{}, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := client.Do(request)
+	if err != nil {
+		return BatchTranslateOperation{}, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return BatchTranslateOperation{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return BatchTranslateOperation{}, fmt.Errorf("batchTranslateText API error: %s", string(respBody))
+	}
+
+	var raw struct {
+		Name  string `json:"name"`
+		Done  bool   `json:"done"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+		Metadata map[string]interface{} `json:"metadata"`
+	}
+	if err := json.Unmarshal(respBody, &raw); err != nil {
+		return BatchTranslateOperation{}, err
+	}
+
+	op := BatchTranslateOperation{Name: raw.Name, Done: raw.Done, Metadata: raw.Metadata}
+	if raw.Error != nil {
+		op.Error = raw.Error.Message
+	}
+	return op, nil
+}
@@ -0,0 +1,66 @@
+package translate
+
+import "testing"
+
+func TestServiceAccountProjectIDRecognizesServiceAccountKeys(t *testing.T) {
+	key := `{"type":"service_account","project_id":"my-project"}`
+	projectID, ok := serviceAccountProjectID(key)
+	if !ok {
+		t.Fatalf("expected %q to be recognized as a service account key", key)
+	}
+	if projectID != "my-project" {
+		t.Fatalf("expected project ID %q, got %q", "my-project", projectID)
+	}
+}
+
+func TestServiceAccountProjectIDRejectsPlainAPIKeys(t *testing.T) {
+	for _, credential := range []string{
+		"AIzaSyPlainAPIKey",
+		`{"type":"authorized_user"}`,
+		`{"type":"service_account"}`, // no project_id
+		"not json at all",
+	} {
+		if _, ok := serviceAccountProjectID(credential); ok {
+			t.Errorf("expected %q to not be recognized as a service account key", credential)
+		}
+	}
+}
+
+func TestBuildGoogleV3RequestBodyOmitsGlossaryByDefault(t *testing.T) {
+	body := buildGoogleV3RequestBody([]string{"hello"}, "en", "fr", GoogleV3Options{})
+	if body.GlossaryConfig != nil {
+		t.Fatalf("expected no glossary config, got %+v", body.GlossaryConfig)
+	}
+	if body.MimeType != "text/plain" {
+		t.Fatalf("expected default MimeType %q, got %q", "text/plain", body.MimeType)
+	}
+}
+
+func TestBuildGoogleV3RequestBodyWiresModelAndGlossary(t *testing.T) {
+	opts := GoogleV3Options{
+		Model: "nmt",
+		GlossaryConfig: &GoogleGlossaryConfig{
+			Glossary:   "projects/p/locations/global/glossaries/g",
+			IgnoreCase: true,
+		},
+		MimeType: "text/html",
+	}
+
+	body := buildGoogleV3RequestBody([]string{"hello", "world"}, "en", "fr", opts)
+
+	if body.Model != "nmt" {
+		t.Errorf("expected Model %q, got %q", "nmt", body.Model)
+	}
+	if body.MimeType != "text/html" {
+		t.Errorf("expected MimeType %q, got %q", "text/html", body.MimeType)
+	}
+	if body.GlossaryConfig == nil {
+		t.Fatalf("expected a glossary config, got nil")
+	}
+	if body.GlossaryConfig.Glossary != opts.GlossaryConfig.Glossary || !body.GlossaryConfig.IgnoreCase {
+		t.Errorf("expected glossary config %+v, got %+v", opts.GlossaryConfig, body.GlossaryConfig)
+	}
+	if len(body.Contents) != 2 || body.Contents[0] != "hello" || body.Contents[1] != "world" {
+		t.Errorf("expected Contents to carry both input texts unchanged, got %v", body.Contents)
+	}
+}
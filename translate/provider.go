@@ -0,0 +1,29 @@
+package translate
+
+import "context"
+
+// TranslationRequest is the backend-agnostic input passed to a Translator.
+type TranslationRequest struct {
+	SourceLang string   // Source language, "" or "auto" lets the backend decide
+	TargetLang string   // Target language
+	Texts      []string // One or more strings to translate
+	ProxyURL   string   // Optional proxy, same semantics as TranslateByGoogle
+}
+
+// Translator is implemented by every translation backend (Google, DeepL, ...)
+// so the Router can try them interchangeably.
+type Translator interface {
+	// Translate performs the translation and returns the same result type
+	// every backend in this package already returns.
+	Translate(ctx context.Context, req TranslationRequest) (DeepLXTranslationResult, error)
+
+	// Name identifies the backend, e.g. "google" or "deepl".
+	Name() string
+
+	// SupportedLanguages returns the language codes the backend accepts, or
+	// nil if the backend does not expose this information up front.
+	SupportedLanguages() []string
+
+	// HealthCheck reports whether the backend is currently reachable.
+	HealthCheck(ctx context.Context) error
+}
@@ -0,0 +1,254 @@
+package translate
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// ProxyProvider abstracts how a transport for an outgoing translation
+// request is obtained, for setups beyond a single HTTP proxy: SOCKS5,
+// rotating pools, health-checked pools. TranslateByGoogle and friends take
+// only a plain proxyURL string (the original behaviour); none of them has a
+// ProxyProvider parameter, and a ProxyProvider reaches them only through
+// DefaultProxyProvider when that string is empty.
+type ProxyProvider interface {
+	// Next returns the transport to use for one request, along with a label
+	// identifying which backing proxy was chosen (for logging and Report).
+	Next() (http.RoundTripper, string, error)
+
+	// Report records whether the request made through the transport/label
+	// returned by Next succeeded, so pools can retire failing proxies.
+	Report(label string, err error)
+}
+
+// DefaultProxyProvider is used by TranslateByGoogle and friends whenever an
+// empty proxyURL is passed and this is non-nil. It lets callers configure
+// proxying once at startup instead of threading a URL through every call.
+var DefaultProxyProvider ProxyProvider
+
+// StaticHTTPProxy routes every request through a single HTTP(S) proxy URL.
+// This is what TranslateByGoogle used before ProxyProvider existed.
+type StaticHTTPProxy struct {
+	URL string
+}
+
+func (p *StaticHTTPProxy) Next() (http.RoundTripper, string, error) {
+	proxyURL, err := url.Parse(p.URL)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	return &http.Transport{Proxy: http.ProxyURL(proxyURL)}, p.URL, nil
+}
+
+func (p *StaticHTTPProxy) Report(string, error) {}
+
+// StaticSOCKS5Proxy routes every request through a single SOCKS5 proxy.
+type StaticSOCKS5Proxy struct {
+	Address  string // host:port
+	Username string
+	Password string
+}
+
+func (p *StaticSOCKS5Proxy) Next() (http.RoundTripper, string, error) {
+	var auth *proxy.Auth
+	if p.Username != "" {
+		auth = &proxy.Auth{User: p.Username, Password: p.Password}
+	}
+	dialer, err := proxy.SOCKS5("tcp", p.Address, auth, proxy.Direct)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create SOCKS5 dialer: %w", err)
+	}
+	contextDialer, ok := dialer.(proxy.ContextDialer)
+	if !ok {
+		return nil, "", fmt.Errorf("SOCKS5 dialer does not support context")
+	}
+	return &http.Transport{DialContext: contextDialer.DialContext}, p.Address, nil
+}
+
+func (p *StaticSOCKS5Proxy) Report(string, error) {}
+
+// poolSelectMode controls how RotatingPool picks the next proxy.
+type poolSelectMode int
+
+const (
+	// PoolRoundRobin cycles through the list in order.
+	PoolRoundRobin poolSelectMode = iota
+	// PoolRandom picks a random entry on every call.
+	PoolRandom
+)
+
+// RotatingPool round-robins or randomly picks one proxy URL per request out
+// of a fixed list, e.g. loaded from a file or an environment variable.
+type RotatingPool struct {
+	Proxies []string
+	Mode    poolSelectMode
+
+	mu   sync.Mutex
+	next int
+}
+
+// NewRotatingPoolFromFile loads one proxy URL per line from path, skipping
+// blank lines and lines starting with "#".
+func NewRotatingPoolFromFile(path string, mode poolSelectMode) (*RotatingPool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open proxy list %q: %w", path, err)
+	}
+	defer f.Close()
+
+	var proxies []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		proxies = append(proxies, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(proxies) == 0 {
+		return nil, fmt.Errorf("proxy list %q is empty", path)
+	}
+	return &RotatingPool{Proxies: proxies, Mode: mode}, nil
+}
+
+// NewRotatingPoolFromEnv loads a comma-separated list of proxy URLs from the
+// named environment variable.
+func NewRotatingPoolFromEnv(envVar string, mode poolSelectMode) (*RotatingPool, error) {
+	raw := os.Getenv(envVar)
+	if raw == "" {
+		return nil, fmt.Errorf("environment variable %q is empty or unset", envVar)
+	}
+	var proxies []string
+	for _, p := range strings.Split(raw, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	if len(proxies) == 0 {
+		return nil, fmt.Errorf("environment variable %q has no usable proxy URLs", envVar)
+	}
+	return &RotatingPool{Proxies: proxies, Mode: mode}, nil
+}
+
+func (p *RotatingPool) Next() (http.RoundTripper, string, error) {
+	p.mu.Lock()
+	var picked string
+	switch p.Mode {
+	case PoolRandom:
+		picked = p.Proxies[rand.Intn(len(p.Proxies))]
+	default:
+		picked = p.Proxies[p.next%len(p.Proxies)]
+		p.next++
+	}
+	p.mu.Unlock()
+
+	return (&StaticHTTPProxy{URL: picked}).Next()
+}
+
+func (p *RotatingPool) Report(string, error) {}
+
+// HealthCheckedPool wraps a RotatingPool's proxy list, picking at random
+// among the proxies that are not retired (Pool.Mode is not used). It retires
+// a proxy after FailureThreshold consecutive failed requests and gives it
+// another chance once RevalidateAfter has passed.
+type HealthCheckedPool struct {
+	Pool             *RotatingPool
+	FailureThreshold int
+	RevalidateAfter  time.Duration
+
+	mu        sync.Mutex
+	failures  map[string]int
+	retiredAt map[string]time.Time
+}
+
+// NewHealthCheckedPool wraps pool, retiring a proxy after failureThreshold
+// consecutive failures and giving it another chance after revalidateAfter.
+func NewHealthCheckedPool(pool *RotatingPool, failureThreshold int, revalidateAfter time.Duration) *HealthCheckedPool {
+	return &HealthCheckedPool{
+		Pool:             pool,
+		FailureThreshold: failureThreshold,
+		RevalidateAfter:  revalidateAfter,
+		failures:         make(map[string]int),
+		retiredAt:        make(map[string]time.Time),
+	}
+}
+
+func (p *HealthCheckedPool) Next() (http.RoundTripper, string, error) {
+	p.mu.Lock()
+	candidates := make([]string, 0, len(p.Pool.Proxies))
+	for _, proxyURL := range p.Pool.Proxies {
+		if retiredAt, retired := p.retiredAt[proxyURL]; retired {
+			if time.Since(retiredAt) < p.RevalidateAfter {
+				continue
+			}
+		}
+		candidates = append(candidates, proxyURL)
+	}
+	p.mu.Unlock()
+
+	if len(candidates) == 0 {
+		return nil, "", fmt.Errorf("no healthy proxies available in pool")
+	}
+
+	picked := candidates[rand.Intn(len(candidates))]
+	transport, _, err := (&StaticHTTPProxy{URL: picked}).Next()
+	return transport, picked, err
+}
+
+func (p *HealthCheckedPool) Report(label string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err == nil {
+		delete(p.failures, label)
+		delete(p.retiredAt, label)
+		return
+	}
+
+	p.failures[label]++
+	if p.failures[label] >= p.FailureThreshold {
+		p.retiredAt[label] = time.Now()
+	}
+}
+
+// buildHTTPClient returns an *http.Client for a single request given the
+// legacy proxyURL string and/or a ProxyProvider, preferring an explicit
+// proxyURL for backward compatibility. If neither is set, DefaultProxyProvider
+// is consulted, then a direct connection is used.
+func buildHTTPClient(proxyURL string, provider ProxyProvider) (client *http.Client, reportBack func(error), err error) {
+	reportBack = func(error) {}
+
+	if proxyURL != "" {
+		transport, _, err := (&StaticHTTPProxy{URL: proxyURL}).Next()
+		if err != nil {
+			return nil, reportBack, err
+		}
+		return &http.Client{Transport: transport}, reportBack, nil
+	}
+
+	if provider == nil {
+		provider = DefaultProxyProvider
+	}
+	if provider == nil {
+		return &http.Client{}, reportBack, nil
+	}
+
+	transport, label, err := provider.Next()
+	if err != nil {
+		return nil, reportBack, err
+	}
+	reportBack = func(err error) { provider.Report(label, err) }
+	return &http.Client{Transport: transport}, reportBack, nil
+}
@@ -0,0 +1,213 @@
+package translate
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var errDialFailed = errors.New("dial failed")
+
+// newHTTPConnectProxy starts a minimal HTTP CONNECT proxy in front of
+// backend and returns its "host:port" address.
+func newHTTPConnectProxy(t *testing.T, backend *httptest.Server) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start fake proxy listener: %v", err)
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveHTTPConnect(conn, backend.Listener.Addr().String())
+		}
+	}()
+	t.Cleanup(func() { ln.Close() })
+	return ln.Addr().String()
+}
+
+func serveHTTPConnect(client net.Conn, backendAddr string) {
+	defer client.Close()
+
+	reader := bufio.NewReader(client)
+	req, err := http.ReadRequest(reader)
+	if err != nil || req.Method != http.MethodConnect {
+		return
+	}
+
+	backend, err := net.Dial("tcp", backendAddr)
+	if err != nil {
+		client.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+		return
+	}
+	defer backend.Close()
+
+	client.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+
+	done := make(chan struct{}, 2)
+	go func() { io.Copy(backend, reader); done <- struct{}{} }()
+	go func() { io.Copy(client, backend); done <- struct{}{} }()
+	<-done
+}
+
+func TestStaticHTTPProxyRoutesThroughConnectProxy(t *testing.T) {
+	backend := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}))
+	defer backend.Close()
+
+	proxyAddr := newHTTPConnectProxy(t, backend)
+
+	provider := &StaticHTTPProxy{URL: "http://" + proxyAddr}
+	transport, label, err := provider.Next()
+	if err != nil {
+		t.Fatalf("Next() returned error: %v", err)
+	}
+	if label != provider.URL {
+		t.Fatalf("expected label %q, got %q", provider.URL, label)
+	}
+	transport.(*http.Transport).TLSClientConfig = backend.Client().Transport.(*http.Transport).TLSClientConfig
+
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get(backend.URL)
+	if err != nil {
+		t.Fatalf("request through proxy failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+// newFakeSOCKS5Server starts a SOCKS5 server (no auth) that tunnels every
+// CONNECT to backendAddr, ignoring the requested destination.
+func newFakeSOCKS5Server(t *testing.T, backendAddr string) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start fake SOCKS5 listener: %v", err)
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeSOCKS5(conn, backendAddr)
+		}
+	}()
+	t.Cleanup(func() { ln.Close() })
+	return ln.Addr().String()
+}
+
+func serveFakeSOCKS5(client net.Conn, backendAddr string) {
+	defer client.Close()
+
+	// Greeting: version, nmethods, methods...
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(client, header); err != nil {
+		return
+	}
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(client, methods); err != nil {
+		return
+	}
+	// No authentication required.
+	client.Write([]byte{0x05, 0x00})
+
+	// Request: version, cmd, rsv, atyp, addr..., port(2)
+	reqHeader := make([]byte, 4)
+	if _, err := io.ReadFull(client, reqHeader); err != nil {
+		return
+	}
+	switch reqHeader[3] {
+	case 0x01: // IPv4
+		io.ReadFull(client, make([]byte, 4+2))
+	case 0x03: // domain name
+		lenBuf := make([]byte, 1)
+		io.ReadFull(client, lenBuf)
+		io.ReadFull(client, make([]byte, int(lenBuf[0])+2))
+	case 0x04: // IPv6
+		io.ReadFull(client, make([]byte, 16+2))
+	default:
+		return
+	}
+
+	backend, err := net.Dial("tcp", backendAddr)
+	if err != nil {
+		client.Write([]byte{0x05, 0x01, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	defer backend.Close()
+
+	// Success reply, ignoring the bound-address details the real server
+	// would report.
+	client.Write([]byte{0x05, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+
+	done := make(chan struct{}, 2)
+	go func() { io.Copy(backend, client); done <- struct{}{} }()
+	go func() { io.Copy(client, backend); done <- struct{}{} }()
+	<-done
+}
+
+func TestStaticSOCKS5ProxyRoutesThroughFakeServer(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}))
+	defer backend.Close()
+
+	socksAddr := newFakeSOCKS5Server(t, backend.Listener.Addr().String())
+
+	provider := &StaticSOCKS5Proxy{Address: socksAddr}
+	transport, label, err := provider.Next()
+	if err != nil {
+		t.Fatalf("Next() returned error: %v", err)
+	}
+	if label != socksAddr {
+		t.Fatalf("expected label %q, got %q", socksAddr, label)
+	}
+
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get(backend.URL)
+	if err != nil {
+		t.Fatalf("request through SOCKS5 failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestHealthCheckedPoolRetiresFailingProxy(t *testing.T) {
+	pool := &RotatingPool{Proxies: []string{"http://bad-proxy.invalid:9"}}
+	checked := NewHealthCheckedPool(pool, 2, time.Hour)
+
+	transport, label, err := checked.Next()
+	if err != nil {
+		t.Fatalf("Next() returned error: %v", err)
+	}
+	if transport == nil || label == "" {
+		t.Fatalf("expected a transport and label before any failures")
+	}
+
+	checked.Report(label, errDialFailed)
+	checked.Report(label, errDialFailed)
+
+	if _, _, err := checked.Next(); err == nil {
+		t.Fatalf("expected proxy to be retired after reaching the failure threshold")
+	}
+}
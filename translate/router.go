@@ -0,0 +1,145 @@
+package translate
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// RouterStrategy selects how a Router picks a provider for a given request.
+type RouterStrategy string
+
+const (
+	// StrategyFirstSuccess tries providers in priority order and returns the
+	// first one that succeeds, falling through to the next on error.
+	StrategyFirstSuccess RouterStrategy = "first-success"
+	// StrategyWeightedRandom picks a provider at random, weighted by Weight.
+	StrategyWeightedRandom RouterStrategy = "weighted-random"
+	// StrategyRoundRobin cycles through providers in order on every call.
+	StrategyRoundRobin RouterStrategy = "round-robin"
+)
+
+// ProviderEntry is one backend registered with a Router, along with its
+// priority/weight in the routing decision.
+type ProviderEntry struct {
+	Translator Translator
+	// Weight is used by StrategyWeightedRandom; ignored by other strategies.
+	Weight float64
+	// Fallback marks a provider that is only tried after every non-fallback
+	// provider has failed, regardless of strategy.
+	Fallback bool
+}
+
+// Router tries a set of Translator backends according to a Strategy and
+// returns the first usable result, e.g. "google:0.7, deepl:0.3, libre:fallback".
+type Router struct {
+	Providers []ProviderEntry
+	Strategy  RouterStrategy
+
+	next int // round-robin cursor
+}
+
+// NewRouter builds a Router over the given providers using strategy.
+func NewRouter(strategy RouterStrategy, providers ...ProviderEntry) *Router {
+	return &Router{Providers: providers, Strategy: strategy}
+}
+
+// providerError reports a non-2xx or failed health check from a Translator.
+type providerError struct {
+	provider string
+	code     int
+	message  string
+}
+
+func (e *providerError) Error() string {
+	return fmt.Sprintf("provider %s returned %d: %s", e.provider, e.code, e.message)
+}
+
+// Translate dispatches req to one or more providers depending on Strategy,
+// returning the first non-error result.
+func (r *Router) Translate(ctx context.Context, req TranslationRequest) (DeepLXTranslationResult, error) {
+	primary, fallback := r.ordered()
+
+	switch r.Strategy {
+	case StrategyWeightedRandom:
+		primary = weightedShuffle(primary)
+	case StrategyRoundRobin:
+		primary = rotated(primary, r.next)
+		r.next = (r.next + 1) % max(1, len(primary))
+	case StrategyFirstSuccess:
+		// already in priority order
+	}
+
+	var lastErr error
+	for _, entry := range append(primary, fallback...) {
+		result, err := entry.Translator.Translate(ctx, req)
+		if err == nil && result.Code == 200 {
+			return result, nil
+		}
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = &providerError{provider: entry.Translator.Name(), code: result.Code, message: result.Message}
+		}
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no translation provider configured")
+	}
+	return DeepLXTranslationResult{
+		Code:    503,
+		Message: fmt.Sprintf("all providers failed: %v", lastErr),
+	}, lastErr
+}
+
+func (r *Router) ordered() (primary, fallback []ProviderEntry) {
+	for _, p := range r.Providers {
+		if p.Fallback {
+			fallback = append(fallback, p)
+		} else {
+			primary = append(primary, p)
+		}
+	}
+	return primary, fallback
+}
+
+func weightedShuffle(entries []ProviderEntry) []ProviderEntry {
+	total := 0.0
+	for _, e := range entries {
+		total += e.Weight
+	}
+	if total <= 0 {
+		return entries
+	}
+
+	remaining := append([]ProviderEntry(nil), entries...)
+	ordered := make([]ProviderEntry, 0, len(entries))
+	for len(remaining) > 0 {
+		pick := rand.Float64() * total
+		for i, e := range remaining {
+			pick -= e.Weight
+			if pick <= 0 {
+				ordered = append(ordered, e)
+				total -= e.Weight
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+		}
+	}
+	return ordered
+}
+
+func rotated(entries []ProviderEntry, start int) []ProviderEntry {
+	if len(entries) == 0 {
+		return entries
+	}
+	start = start % len(entries)
+	return append(append([]ProviderEntry(nil), entries[start:]...), entries[:start]...)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
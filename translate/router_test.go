@@ -0,0 +1,146 @@
+package translate
+
+import (
+	"context"
+	"testing"
+)
+
+// recordingTranslator appends its name to calls on every Translate call, so
+// tests can assert both the outcome and the order providers were tried in.
+type recordingTranslator struct {
+	name  string
+	fail  bool
+	calls *[]string
+}
+
+func (r *recordingTranslator) Translate(_ context.Context, req TranslationRequest) (DeepLXTranslationResult, error) {
+	*r.calls = append(*r.calls, r.name)
+	if r.fail {
+		return DeepLXTranslationResult{Code: 503, Message: "down"}, nil
+	}
+	return DeepLXTranslationResult{Code: 200, Data: r.name, Method: r.name}, nil
+}
+
+func (r *recordingTranslator) Name() string                      { return r.name }
+func (r *recordingTranslator) SupportedLanguages() []string      { return nil }
+func (r *recordingTranslator) HealthCheck(context.Context) error { return nil }
+
+func TestRouterFirstSuccessReturnsFirstWorkingProvider(t *testing.T) {
+	var calls []string
+	router := NewRouter(StrategyFirstSuccess,
+		ProviderEntry{Translator: &recordingTranslator{name: "p1", fail: true, calls: &calls}},
+		ProviderEntry{Translator: &recordingTranslator{name: "p2", calls: &calls}},
+		ProviderEntry{Translator: &recordingTranslator{name: "p3", calls: &calls}},
+	)
+
+	result, err := router.Translate(context.Background(), TranslationRequest{Texts: []string{"hi"}})
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if result.Method != "p2" {
+		t.Fatalf("expected p2 to succeed, got %q", result.Method)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("expected p3 to never be tried once p2 succeeded, got call order %v", calls)
+	}
+}
+
+func TestRouterFallbackIsTriedLastRegardlessOfStrategy(t *testing.T) {
+	var calls []string
+	router := NewRouter(StrategyRoundRobin,
+		ProviderEntry{Translator: &recordingTranslator{name: "p1", fail: true, calls: &calls}},
+		ProviderEntry{Translator: &recordingTranslator{name: "p2", fail: true, calls: &calls}},
+		ProviderEntry{Translator: &recordingTranslator{name: "fb", calls: &calls}, Fallback: true},
+	)
+
+	result, err := router.Translate(context.Background(), TranslationRequest{Texts: []string{"hi"}})
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if result.Method != "fb" {
+		t.Fatalf("expected the fallback provider to be used, got %q", result.Method)
+	}
+	if calls[len(calls)-1] != "fb" {
+		t.Fatalf("expected fallback to be tried last, got call order %v", calls)
+	}
+}
+
+func TestRouterReturnsErrorWhenAllProvidersFail(t *testing.T) {
+	var calls []string
+	router := NewRouter(StrategyFirstSuccess,
+		ProviderEntry{Translator: &recordingTranslator{name: "p1", fail: true, calls: &calls}},
+	)
+
+	_, err := router.Translate(context.Background(), TranslationRequest{Texts: []string{"hi"}})
+	if err == nil {
+		t.Fatal("expected an error when every provider fails")
+	}
+}
+
+func TestWeightedShuffleReturnsPermutationOfInput(t *testing.T) {
+	var calls []string
+	entries := []ProviderEntry{
+		{Translator: &recordingTranslator{name: "a", calls: &calls}, Weight: 1},
+		{Translator: &recordingTranslator{name: "b", calls: &calls}, Weight: 2},
+		{Translator: &recordingTranslator{name: "c", calls: &calls}, Weight: 3},
+	}
+
+	shuffled := weightedShuffle(entries)
+	if len(shuffled) != len(entries) {
+		t.Fatalf("expected %d entries, got %d", len(entries), len(shuffled))
+	}
+	seen := make(map[string]bool, len(shuffled))
+	for _, e := range shuffled {
+		seen[e.Translator.Name()] = true
+	}
+	for _, e := range entries {
+		if !seen[e.Translator.Name()] {
+			t.Fatalf("weightedShuffle dropped entry %q: got %v", e.Translator.Name(), shuffled)
+		}
+	}
+}
+
+func TestWeightedShuffleWithNoWeightLeavesOrderUnchanged(t *testing.T) {
+	var calls []string
+	entries := []ProviderEntry{
+		{Translator: &recordingTranslator{name: "a", calls: &calls}},
+		{Translator: &recordingTranslator{name: "b", calls: &calls}},
+	}
+
+	shuffled := weightedShuffle(entries)
+	for i, e := range entries {
+		if shuffled[i].Translator.Name() != e.Translator.Name() {
+			t.Fatalf("expected zero-total-weight entries to pass through unchanged, got %v", shuffled)
+		}
+	}
+}
+
+func TestRotatedCyclesEntriesFromStart(t *testing.T) {
+	var calls []string
+	entries := []ProviderEntry{
+		{Translator: &recordingTranslator{name: "a", calls: &calls}},
+		{Translator: &recordingTranslator{name: "b", calls: &calls}},
+		{Translator: &recordingTranslator{name: "c", calls: &calls}},
+	}
+
+	got := rotated(entries, 1)
+	want := []string{"b", "c", "a"}
+	for i, w := range want {
+		if got[i].Translator.Name() != w {
+			t.Fatalf("rotated(entries, 1)[%d] = %q, want %q", i, got[i].Translator.Name(), w)
+		}
+	}
+}
+
+func TestRotatedWrapsStartModuloLength(t *testing.T) {
+	var calls []string
+	entries := []ProviderEntry{
+		{Translator: &recordingTranslator{name: "a", calls: &calls}},
+		{Translator: &recordingTranslator{name: "b", calls: &calls}},
+	}
+
+	got := rotated(entries, 3)
+	if got[0].Translator.Name() != "b" || got[1].Translator.Name() != "a" {
+		t.Fatalf("rotated(entries, 3) = %v, want [b a]", got)
+	}
+}
@@ -0,0 +1,190 @@
+package translate
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// googleMaxSegmentsPerRequest and googleMaxBytesPerRequest are Google Cloud
+// Translation V2's documented limits: at most 128 Q[] entries and 30KB of
+// text per request.
+const (
+	googleMaxSegmentsPerRequest = 128
+	googleMaxBytesPerRequest    = 30 * 1024
+)
+
+// BatchRequest is the input to TranslateStream.
+type BatchRequest struct {
+	SourceLang string
+	TargetLang string
+	Texts      []string
+	APIKey     string
+	ProxyURL   string
+
+	// Concurrency bounds how many chunk requests are in flight at once.
+	// Defaults to 4 when <= 0.
+	Concurrency int
+}
+
+// TranslationEvent reports the outcome of translating req.Texts[Index]:
+// either Text is set, or Err is, never both.
+type TranslationEvent struct {
+	Index int
+	Text  string
+	Err   error
+}
+
+// textChunk is one group of texts that fits within Google's per-request
+// limits, along with the index of its first text in the original slice.
+type textChunk struct {
+	startIndex int
+	texts      []string
+}
+
+// chunkTexts splits texts into groups of at most googleMaxSegmentsPerRequest
+// entries and googleMaxBytesPerRequest total bytes. A single text longer
+// than googleMaxBytesPerRequest is not split; it gets a chunk of its own.
+func chunkTexts(texts []string) []textChunk {
+	var chunks []textChunk
+	var current []string
+	currentBytes := 0
+
+	flush := func(at int) {
+		if len(current) == 0 {
+			return
+		}
+		chunks = append(chunks, textChunk{startIndex: at - len(current), texts: current})
+		current = nil
+		currentBytes = 0
+	}
+
+	for i, text := range texts {
+		if len(current) >= googleMaxSegmentsPerRequest || currentBytes+len(text) > googleMaxBytesPerRequest {
+			flush(i)
+		}
+		current = append(current, text)
+		currentBytes += len(text)
+	}
+	flush(len(texts))
+
+	return chunks
+}
+
+// TranslateStream splits req.Texts into chunks respecting Google's
+// 128-segment / 30KB-per-request limits and translates them concurrently
+// (bounded by req.Concurrency), emitting one TranslationEvent per input
+// text on the returned channel as soon as its chunk completes, so callers
+// can render results progressively instead of waiting for the whole batch.
+// The channel is closed once every chunk has been attempted or ctx is done.
+func TranslateStream(ctx context.Context, req BatchRequest) (<-chan TranslationEvent, error) {
+	if len(req.Texts) == 0 {
+		return nil, &providerError{provider: "google", code: http.StatusBadRequest, message: "No text to translate"}
+	}
+
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	chunks := chunkTexts(req.Texts)
+	events := make(chan TranslationEvent, len(req.Texts))
+	sem := make(chan struct{}, concurrency)
+	done := make(chan struct{}, len(chunks))
+
+	for _, chunk := range chunks {
+		chunk := chunk
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				emitChunkError(ctx, events, chunk, ctx.Err())
+				return
+			}
+			defer func() { <-sem }()
+
+			translations, err := translateChunkWithRetry(ctx, req, chunk.texts)
+			if err != nil {
+				emitChunkError(ctx, events, chunk, err)
+				return
+			}
+			for i, text := range translations {
+				select {
+				case events <- TranslationEvent{Index: chunk.startIndex + i, Text: text}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for range chunks {
+			<-done
+		}
+		close(events)
+	}()
+
+	return events, nil
+}
+
+func emitChunkError(ctx context.Context, events chan<- TranslationEvent, chunk textChunk, err error) {
+	for i := range chunk.texts {
+		select {
+		case events <- TranslationEvent{Index: chunk.startIndex + i, Err: err}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// isRetryableStatus reports whether statusCode is one TranslateStream should
+// retry after a backoff: rate limiting or a transient server-side failure.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// translateChunkWithRetry calls googleV2RawTranslate, retrying with
+// exponential backoff on isRetryableStatus responses until it succeeds, a
+// non-retryable error is returned, retries are exhausted, or ctx is done.
+func translateChunkWithRetry(ctx context.Context, req BatchRequest, texts []string) ([]string, error) {
+	const maxAttempts = 5
+	backoff := 500 * time.Millisecond
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		translations, statusCode, err := googleV2RawTranslate(ctx, req.SourceLang, req.TargetLang, texts, req.APIKey, req.ProxyURL)
+		if err == nil && statusCode == http.StatusOK {
+			return translations, nil
+		}
+
+		lastErr = err
+		if lastErr == nil {
+			lastErr = &providerError{provider: "google", code: statusCode, message: "translation failed"}
+		}
+		if !isRetryableStatus(statusCode) {
+			return nil, lastErr
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff *= 2
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,89 @@
+package translate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChunkTextsRespectsSegmentLimit(t *testing.T) {
+	texts := make([]string, googleMaxSegmentsPerRequest+10)
+	for i := range texts {
+		texts[i] = "hi"
+	}
+
+	chunks := chunkTexts(texts)
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d", len(chunks))
+	}
+	if len(chunks[0].texts) != googleMaxSegmentsPerRequest {
+		t.Fatalf("expected first chunk to have %d texts, got %d", googleMaxSegmentsPerRequest, len(chunks[0].texts))
+	}
+	if chunks[1].startIndex != googleMaxSegmentsPerRequest {
+		t.Fatalf("expected second chunk to start at index %d, got %d", googleMaxSegmentsPerRequest, chunks[1].startIndex)
+	}
+}
+
+func TestChunkTextsRespectsByteLimit(t *testing.T) {
+	big := make([]byte, googleMaxBytesPerRequest-1)
+	for i := range big {
+		big[i] = 'a'
+	}
+	texts := []string{string(big), "overflow"}
+
+	chunks := chunkTexts(texts)
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d", len(chunks))
+	}
+	if chunks[1].startIndex != 1 {
+		t.Fatalf("expected second chunk to start at index 1, got %d", chunks[1].startIndex)
+	}
+}
+
+// TestGoogleV2RawTranslateAbortsOnCanceledContext guards against ctx only
+// aborting the retry backoff sleep between attempts instead of the HTTP
+// call itself: with ctx already canceled, googleV2RawTranslate must fail
+// fast with ctx's error rather than dialing out.
+func TestGoogleV2RawTranslateAbortsOnCanceledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("server should not have been contacted once ctx was canceled")
+	}))
+	defer server.Close()
+
+	originalBaseURL := googleV2BaseURL
+	googleV2BaseURL = server.URL
+	defer func() { googleV2BaseURL = originalBaseURL }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _, err := googleV2RawTranslate(ctx, "en", "fr", []string{"hello"}, "test-key", "")
+		if err == nil {
+			t.Error("expected an error from a canceled context, got nil")
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("googleV2RawTranslate did not return promptly for an already-canceled context")
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	for _, code := range []int{429, 500, 502, 503, 504} {
+		if !isRetryableStatus(code) {
+			t.Errorf("expected status %d to be retryable", code)
+		}
+	}
+	for _, code := range []int{200, 400, 401, 403, 404} {
+		if isRetryableStatus(code) {
+			t.Errorf("expected status %d to not be retryable", code)
+		}
+	}
+}
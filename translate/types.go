@@ -0,0 +1,19 @@
+package translate
+
+// DeepLXTranslationResult is the result type shared by every backend in
+// this package, modeled on DeepLX's response shape. Data holds the
+// translation for a single input text (the common case); Translations
+// holds one entry per text in the batch that produced this result, in
+// order, for callers translating more than one text at once. Joining
+// Translations with a separator to populate Data would be lossy whenever a
+// translated segment itself contains that separator, so callers that care
+// about per-input results must use Translations, not Data.
+type DeepLXTranslationResult struct {
+	Code         int
+	Message      string
+	Data         string
+	Translations []string
+	SourceLang   string
+	TargetLang   string
+	Method       string
+}